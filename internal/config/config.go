@@ -4,14 +4,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"strings"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -20,6 +21,7 @@ const (
 	defaultIntervalSeconds = 30
 	defaultMetricConfig    = ":9194"
 	maxStatsBucketSeconds  = 7200
+	envVarPrefix           = "$ENV_"
 )
 
 var (
@@ -29,13 +31,14 @@ var (
 )
 
 type Config struct {
-	Placement     string `json:"placement,omitempty" validate:"required"`
-	MetricConfig  string `json:"metrics_addr,omitempty" validate:"omitempty,tcp_addr"`
-	IntervalSecs  int    `json:"interval_s,omitempty" validate:"min=1,max=300"`
-	StatIntervals []int  `json:"stat_intervals,omitempty" validate:"dive,min=10,max=3600"`
-	LogSensor     bool   `json:"log_sensor,omitempty"`
-	MqttConfig
-	SensorConfig
+	Placement     string `json:"placement,omitempty" yaml:"placement,omitempty" validate:"required"`
+	MetricConfig  string `json:"metrics_addr,omitempty" yaml:"metrics_addr,omitempty" validate:"omitempty,tcp_addr"`
+	IntervalSecs  int    `json:"interval_s,omitempty" yaml:"interval_s,omitempty" validate:"min=1,max=300"`
+	StatIntervals []int  `json:"stat_intervals,omitempty" yaml:"stat_intervals,omitempty" validate:"dive,min=10,max=3600"`
+	LogSensor     bool   `json:"log_sensor,omitempty" yaml:"log_sensor,omitempty"`
+	LogFormat     string `json:"log_format,omitempty" yaml:"log_format,omitempty" validate:"omitempty,oneof=text json"`
+	MqttConfig    `yaml:",inline"`
+	SensorConfig  `yaml:",inline"`
 }
 
 func DefaultConfig() Config {
@@ -44,6 +47,8 @@ func DefaultConfig() Config {
 		IntervalSecs:  defaultIntervalSeconds,
 		MetricConfig:  defaultMetricConfig,
 		StatIntervals: defaultStatsBucketsSeconds,
+		LogFormat:     LogFormatText,
+		MqttConfig:    defaultMqttConfig(),
 		SensorConfig:  defaultSensorConfig(),
 	}
 }
@@ -96,6 +101,7 @@ func ConfigFromEnv() Config {
 		conf.ClientCertFile = clientCertFile
 	}
 
+	conf.MqttConfig.FoldHosts()
 	conf.SensorConfig.ConfigFromEnv()
 
 	return conf
@@ -108,18 +114,112 @@ func ReadJsonConfig(filePath string) (*Config, error) {
 	}
 
 	ret := DefaultConfig()
-	err = json.Unmarshal(fileContent, &ret)
-	return &ret, err
+	if err := json.Unmarshal(fileContent, &ret); err != nil {
+		return nil, err
+	}
+
+	if err := resolveEnvVars(reflect.ValueOf(&ret).Elem()); err != nil {
+		return nil, fmt.Errorf("could not resolve environment variables: %v", err)
+	}
+	ret.MqttConfig.FoldHosts()
+
+	return &ret, nil
+}
+
+func ReadYamlConfig(filePath string) (*Config, error) {
+	fileContent, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config from file: %v", err)
+	}
+
+	ret := DefaultConfig()
+	if err := yaml.Unmarshal(fileContent, &ret); err != nil {
+		return nil, err
+	}
+
+	if err := resolveEnvVars(reflect.ValueOf(&ret).Elem()); err != nil {
+		return nil, fmt.Errorf("could not resolve environment variables: %v", err)
+	}
+	ret.MqttConfig.FoldHosts()
+
+	return &ret, nil
+}
+
+// LoadConfig reads a config file, picking the JSON or YAML parser based on the
+// file's extension (".json" vs. ".yml"/".yaml").
+func LoadConfig(filePath string) (*Config, error) {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yml", ".yaml":
+		return ReadYamlConfig(filePath)
+	case ".json":
+		return ReadJsonConfig(filePath)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension: %q", filepath.Ext(filePath))
+	}
+}
+
+// resolveEnvVars walks a struct's fields and replaces any string value carrying the
+// "$ENV_" prefix with the value of the corresponding environment variable, e.g.
+// "$ENV_HOME_MQTT_PW" is replaced with the value of the "HOME_MQTT_PW" env var. Nested
+// (and embedded) structs are walked recursively. Missing env vars referenced by a field
+// tagged "required" are reported as an error; other fields are left untouched.
+func resolveEnvVars(v reflect.Value) error {
+	t := v.Type()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Struct:
+			if err := resolveEnvVars(field); err != nil {
+				return err
+			}
+		case reflect.String:
+			value := field.String()
+			if !strings.HasPrefix(value, envVarPrefix) {
+				continue
+			}
+
+			envName := strings.TrimPrefix(value, envVarPrefix)
+			envValue, ok := os.LookupEnv(envName)
+			if !ok {
+				if isRequired(t.Field(i).Tag.Get("validate")) {
+					return fmt.Errorf("environment variable %q referenced by field %q is not set", envName, t.Field(i).Name)
+				}
+				continue
+			}
+
+			field.SetString(envValue)
+		}
+	}
+
+	return nil
+}
+
+// isRequired reports whether a validator "validate" tag carries the plain "required"
+// rule. It only matches the exact rule name so that conditional variants such as
+// "required_without=Hosts" - under which the field may legitimately be empty - are not
+// mistaken for an unconditional requirement.
+func isRequired(tag string) bool {
+	for _, rule := range strings.Split(tag, ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
 }
 
 func (conf *Config) Validate() error {
+	conf.MqttConfig.FoldHosts()
+	ensureLoggerFormat(conf.LogFormat)
+
 	once.Do(func() {
 		validate = validator.New()
 		if err := validate.RegisterValidation("mqtt_topic", validateTopic); err != nil {
-			log.Fatal("could not build custom validation 'mqtt_topic'")
+			currentLogger().Error("could not build custom validator", "validator", "mqtt_topic", "error", err)
+			panic(err)
 		}
 		if err := validate.RegisterValidation("mqtt_broker", validateBroker); err != nil {
-			log.Fatal("could not build custom validation 'validateBroker'")
+			currentLogger().Error("could not build custom validator", "validator", "mqtt_broker", "error", err)
+			panic(err)
 		}
 	})
 	return validate.Struct(conf)
@@ -193,24 +293,25 @@ func (conf *Config) Validat2e() error {
 }
 
 func (conf *Config) Print() {
-	log.Println("-----------------")
-	log.Println("Configuration:")
-	log.Printf("Placement=%s", conf.Placement)
-	log.Printf("LogSensor=%t", conf.LogSensor)
-	log.Printf("MetricConfig=%s", conf.MetricConfig)
-	log.Printf("IntervalSecs=%d", conf.IntervalSecs)
-	log.Printf("Host=%s", conf.Host)
-	log.Printf("Topic=%s", conf.Topic)
+	currentLogger().Info("configuration",
+		"placement", conf.Placement,
+		"log_sensor", conf.LogSensor,
+		"metrics_addr", conf.MetricConfig,
+		"interval_s", conf.IntervalSecs,
+		"hosts", conf.Hosts,
+		"topic", conf.Topic,
+		"connect_timeout_ms", conf.ConnectTimeoutMs,
+		"max_reconnect_interval_ms", conf.MaxReconnectIntervalMs,
+		"keep_alive_s", conf.KeepAliveSecs,
+	)
 	if len(conf.MqttConfig.StatsTopic) > 0 {
-		log.Printf("StatsTopic=%s", conf.Topic)
+		currentLogger().Info("configuration", "stats_topic", conf.StatsTopic)
 	}
 	if len(conf.StatIntervals) > 0 {
-		log.Printf("StatIntervals=%v", conf.StatIntervals)
+		currentLogger().Info("configuration", "stat_intervals", conf.StatIntervals)
 	}
 
 	conf.SensorConfig.Print()
-
-	log.Println("-----------------")
 }
 
 func computeEnvName(name string) string {
@@ -0,0 +1,154 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadJsonConfig_ResolvesEnvVars(t *testing.T) {
+	t.Setenv("HOME_MQTT_PW", "s3cr3t")
+	t.Setenv("HOME_MQTT_TOPIC", "home/lux")
+
+	content := `{
+		"placement": "living_room",
+		"mqtt_host": "$ENV_HOME_MQTT_PW",
+		"mqtt_topic": "$ENV_HOME_MQTT_TOPIC"
+	}`
+
+	path := writeTempConfig(t, content)
+
+	conf, err := ReadJsonConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if conf.Host != "s3cr3t" {
+		t.Errorf("expected Host to be resolved from env, got %q", conf.Host)
+	}
+	if conf.Topic != "home/lux" {
+		t.Errorf("expected Topic to be resolved from env, got %q", conf.Topic)
+	}
+}
+
+func TestReadJsonConfig_MissingEnvVarOnRequiredField(t *testing.T) {
+	content := `{
+		"placement": "$ENV_DOES_NOT_EXIST"
+	}`
+
+	path := writeTempConfig(t, content)
+
+	_, err := ReadJsonConfig(path)
+	if err == nil {
+		t.Fatal("expected an error for a missing required environment variable, got nil")
+	}
+}
+
+func TestReadJsonConfig_MissingEnvVarOnOptionalFieldIsIgnored(t *testing.T) {
+	content := `{
+		"placement": "living_room",
+		"mqtt_stats_topic": "$ENV_DOES_NOT_EXIST"
+	}`
+
+	path := writeTempConfig(t, content)
+
+	conf, err := ReadJsonConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.StatsTopic != "$ENV_DOES_NOT_EXIST" {
+		t.Errorf("expected unresolved optional field to be left untouched, got %q", conf.StatsTopic)
+	}
+}
+
+func TestReadYamlConfig(t *testing.T) {
+	content := `
+placement: living_room
+mqtt_host: broker.local
+mqtt_topic: home/lux
+stat_intervals: [15, 30, 60]
+`
+	path := writeTempConfigWithExt(t, content, ".yaml")
+
+	conf, err := ReadYamlConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if conf.Placement != "living_room" {
+		t.Errorf("expected Placement to be %q, got %q", "living_room", conf.Placement)
+	}
+	if conf.Host != "broker.local" {
+		t.Errorf("expected Host to be %q, got %q", "broker.local", conf.Host)
+	}
+}
+
+func TestLoadConfig_DispatchesByExtension(t *testing.T) {
+	jsonPath := writeTempConfigWithExt(t, `{"placement": "kitchen"}`, ".json")
+	yamlPath := writeTempConfigWithExt(t, "placement: kitchen\n", ".yml")
+
+	for _, path := range []string{jsonPath, yamlPath} {
+		conf, err := LoadConfig(path)
+		if err != nil {
+			t.Fatalf("unexpected error loading %s: %v", path, err)
+		}
+		if conf.Placement != "kitchen" {
+			t.Errorf("expected Placement to be %q, got %q", "kitchen", conf.Placement)
+		}
+	}
+
+	if _, err := LoadConfig("config.txt"); err == nil {
+		t.Error("expected an error for an unsupported file extension, got nil")
+	}
+}
+
+func TestReadJsonConfig_MissingEnvVarOnConditionallyRequiredFieldIsIgnored(t *testing.T) {
+	content := `{
+		"placement": "living_room",
+		"mqtt_hosts": ["broker-a.local", "broker-b.local"],
+		"mqtt_host": "$ENV_DOES_NOT_EXIST",
+		"mqtt_topic": "home/lux"
+	}`
+
+	path := writeTempConfig(t, content)
+
+	conf, err := ReadJsonConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conf.Host != "$ENV_DOES_NOT_EXIST" {
+		t.Errorf("expected Host (required_without=Hosts, and Hosts is set) to be left untouched, got %q", conf.Host)
+	}
+}
+
+func TestReadJsonConfig_FoldsLegacyHostIntoHostsBeforeValidate(t *testing.T) {
+	content := `{
+		"placement": "living_room",
+		"mqtt_host": "broker.local",
+		"mqtt_topic": "home/lux"
+	}`
+
+	path := writeTempConfig(t, content)
+
+	conf, err := ReadJsonConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(conf.Hosts) != 1 || conf.Hosts[0] != "broker.local" {
+		t.Errorf("expected Hosts to already contain the legacy Host before Validate() runs, got %v", conf.Hosts)
+	}
+}
+
+func writeTempConfig(t *testing.T, content string) string {
+	return writeTempConfigWithExt(t, content, ".json")
+}
+
+func writeTempConfigWithExt(t *testing.T, content, ext string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config"+ext)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("could not write temp config: %v", err)
+	}
+	return path
+}
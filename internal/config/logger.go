@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync/atomic"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	LogFormatText = "text"
+	LogFormatJson = "json"
+)
+
+// Logger is the structured logging interface used throughout this package. msg carries
+// the human-readable event; kv are alternating key/value pairs describing it, e.g.
+// Info("sample taken", "placement", "living_room", "lux", 412).
+type Logger interface {
+	Debug(msg string, kv ...any)
+	Info(msg string, kv ...any)
+	Warn(msg string, kv ...any)
+	Error(msg string, kv ...any)
+}
+
+// loggerState pairs the package-wide logger with the format it was built for, so
+// repeated Validate() calls can tell whether the logger actually needs rebuilding.
+// It is always replaced wholesale (never mutated in place) so it can be read and
+// swapped from concurrent goroutines - e.g. Watch's reload loop racing a
+// concurrent Config.Print call that's logging through currentLogger() - without a
+// data race.
+type loggerState struct {
+	logger Logger
+	format string
+}
+
+// loggerPtr holds the current *loggerState. It defaults to a stdlib-backed text
+// logger and is swapped out for a zerolog-backed one as soon as Validate() is
+// called on a Config with LogFormat "json" - before, and regardless of whether,
+// that Config passes validation.
+var loggerPtr atomic.Pointer[loggerState]
+
+func init() {
+	loggerPtr.Store(&loggerState{logger: newStdLogger(), format: LogFormatText})
+}
+
+// currentLogger returns the package-wide logger used by Config.Print, Validate and
+// the rest of this package.
+func currentLogger() Logger {
+	return loggerPtr.Load().logger
+}
+
+// SetLogger replaces the package-wide logger.
+func SetLogger(l Logger) {
+	loggerPtr.Store(&loggerState{logger: l})
+}
+
+// ensureLoggerFormat swaps in a logger built for format, unless the current logger
+// was already built for that format - so e.g. Watch's per-reload Validate() calls
+// don't rebuild (and churn allocate) a new logger on every single reload.
+func ensureLoggerFormat(format string) {
+	if loggerPtr.Load().format == format {
+		return
+	}
+	loggerPtr.Store(&loggerState{logger: NewLogger(format), format: format})
+}
+
+// NewLogger builds a Logger for the given format. LogFormatJson selects a
+// zerolog-backed structured logger; anything else, including the empty string,
+// falls back to the stdlib-backed text logger.
+func NewLogger(format string) Logger {
+	if format == LogFormatJson {
+		return newZerologLogger()
+	}
+	return newStdLogger()
+}
+
+type stdLogger struct {
+	*log.Logger
+}
+
+func newStdLogger() *stdLogger {
+	return &stdLogger{log.New(os.Stderr, "", log.LstdFlags)}
+}
+
+func (l *stdLogger) Debug(msg string, kv ...any) { l.log("DEBUG", msg, kv...) }
+func (l *stdLogger) Info(msg string, kv ...any)  { l.log("INFO", msg, kv...) }
+func (l *stdLogger) Warn(msg string, kv ...any)  { l.log("WARN", msg, kv...) }
+func (l *stdLogger) Error(msg string, kv ...any) { l.log("ERROR", msg, kv...) }
+
+func (l *stdLogger) log(level, msg string, kv ...any) {
+	out := fmt.Sprintf("level=%s msg=%q", level, msg)
+	for i := 0; i+1 < len(kv); i += 2 {
+		out += fmt.Sprintf(" %v=%v", kv[i], kv[i+1])
+	}
+	l.Logger.Println(out)
+}
+
+type zerologLogger struct {
+	logger zerolog.Logger
+}
+
+func newZerologLogger() *zerologLogger {
+	return &zerologLogger{zerolog.New(os.Stderr).With().Timestamp().Logger()}
+}
+
+func (l *zerologLogger) Debug(msg string, kv ...any) { l.event(l.logger.Debug(), msg, kv...) }
+func (l *zerologLogger) Info(msg string, kv ...any)  { l.event(l.logger.Info(), msg, kv...) }
+func (l *zerologLogger) Warn(msg string, kv ...any)  { l.event(l.logger.Warn(), msg, kv...) }
+func (l *zerologLogger) Error(msg string, kv ...any) { l.event(l.logger.Error(), msg, kv...) }
+
+func (l *zerologLogger) event(evt *zerolog.Event, msg string, kv ...any) {
+	for i := 0; i+1 < len(kv); i += 2 {
+		key, ok := kv[i].(string)
+		if !ok {
+			continue
+		}
+		evt = evt.Interface(key, kv[i+1])
+	}
+	evt.Msg(msg)
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestNewLogger(t *testing.T) {
+	if _, ok := NewLogger(LogFormatJson).(*zerologLogger); !ok {
+		t.Errorf("expected NewLogger(%q) to return a zerolog-backed logger", LogFormatJson)
+	}
+
+	for _, format := range []string{LogFormatText, "", "bogus"} {
+		if _, ok := NewLogger(format).(*stdLogger); !ok {
+			t.Errorf("expected NewLogger(%q) to return the stdlib-backed logger", format)
+		}
+	}
+}
+
+// TestValidate_ConcurrentLoggerAccessIsRaceFree exercises Validate() (which rebuilds
+// the logger via ensureLoggerFormat) racing against Print() (which reads it via
+// currentLogger()), the same pattern Watch's reload loop and a concurrently logging
+// sensor/MQTT client would produce. Run with -race to catch regressions.
+func TestValidate_ConcurrentLoggerAccessIsRaceFree(t *testing.T) {
+	confJSON := DefaultConfig()
+	confJSON.Placement = "living_room"
+	confJSON.Host = "127.0.0.1"
+	confJSON.Topic = "home/lux"
+	confJSON.LogFormat = LogFormatJson
+
+	confText := confJSON
+	confText.LogFormat = LogFormatText
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		useJSON := i%2 == 0
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			conf := confText
+			if useJSON {
+				conf = confJSON
+			}
+			_ = conf.Validate()
+		}()
+		go func() {
+			defer wg.Done()
+			confJSON.Print()
+		}()
+	}
+	wg.Wait()
+}
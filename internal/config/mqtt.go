@@ -0,0 +1,68 @@
+package config
+
+import "regexp"
+
+var (
+	topicPattern = regexp.MustCompile(`^[^+#\x00]+$`)
+	hostPattern  = regexp.MustCompile(`^[a-zA-Z0-9.-]+(:\d{1,5})?$`)
+)
+
+const (
+	defaultConnectTimeoutMs       = 5000
+	defaultMaxReconnectIntervalMs = 60000
+	defaultKeepAliveSecs          = 30
+)
+
+// MqttConfig only carries the broker-failover *configuration*: Hosts, folded in from
+// the legacy Host for backward compatibility, plus the timeouts below. It does not
+// itself build a Paho client or round-robin brokers on disconnect - this package has
+// no MQTT client/publisher of its own to wire that into. Whatever constructs the
+// paho.mqtt.golang ClientOptions is responsible for calling AddBroker for every entry
+// in Hosts (in order) and for applying ConnectTimeoutMs/MaxReconnectIntervalMs/
+// KeepAliveSecs to it.
+type MqttConfig struct {
+	// Host is kept for backward compatibility with existing configs; FoldHosts
+	// merges it into Hosts so callers only need to deal with the latter.
+	Host                   string   `json:"mqtt_host,omitempty" yaml:"mqtt_host,omitempty" validate:"required_without=Hosts,omitempty,mqtt_broker"`
+	Hosts                  []string `json:"mqtt_hosts,omitempty" yaml:"mqtt_hosts,omitempty" validate:"required_without=Host,omitempty,dive,mqtt_broker"`
+	Topic                  string   `json:"mqtt_topic,omitempty" yaml:"mqtt_topic,omitempty" validate:"required,mqtt_topic"`
+	StatsTopic             string   `json:"mqtt_stats_topic,omitempty" yaml:"mqtt_stats_topic,omitempty" validate:"omitempty,mqtt_topic"`
+	ClientCertFile         string   `json:"mqtt_client_cert_file,omitempty" yaml:"mqtt_client_cert_file,omitempty"`
+	ClientKeyFile          string   `json:"mqtt_client_key_file,omitempty" yaml:"mqtt_client_key_file,omitempty"`
+	ConnectTimeoutMs       int      `json:"mqtt_connect_timeout_ms,omitempty" yaml:"mqtt_connect_timeout_ms,omitempty" validate:"omitempty,min=1"`
+	MaxReconnectIntervalMs int      `json:"mqtt_max_reconnect_interval_ms,omitempty" yaml:"mqtt_max_reconnect_interval_ms,omitempty" validate:"omitempty,min=1"`
+	KeepAliveSecs          int      `json:"mqtt_keep_alive_s,omitempty" yaml:"mqtt_keep_alive_s,omitempty" validate:"omitempty,min=1"`
+}
+
+func defaultMqttConfig() MqttConfig {
+	return MqttConfig{
+		ConnectTimeoutMs:       defaultConnectTimeoutMs,
+		MaxReconnectIntervalMs: defaultMaxReconnectIntervalMs,
+		KeepAliveSecs:          defaultKeepAliveSecs,
+	}
+}
+
+// FoldHosts merges the legacy single-broker Host field into Hosts so that both old
+// configs (setting only "mqtt_host") and new ones (setting "mqtt_hosts") end up with
+// Hosts as the single source of truth for broker failover.
+func (conf *MqttConfig) FoldHosts() {
+	if conf.Host == "" {
+		return
+	}
+
+	for _, host := range conf.Hosts {
+		if host == conf.Host {
+			return
+		}
+	}
+
+	conf.Hosts = append(conf.Hosts, conf.Host)
+}
+
+func matchTopic(topic string) bool {
+	return len(topic) > 0 && topicPattern.MatchString(topic)
+}
+
+func matchHost(host string) bool {
+	return len(host) > 0 && hostPattern.MatchString(host)
+}
@@ -0,0 +1,46 @@
+package config
+
+import "testing"
+
+func TestMqttConfig_FoldHosts(t *testing.T) {
+	tests := []struct {
+		name  string
+		conf  MqttConfig
+		hosts []string
+	}{
+		{
+			name:  "legacy host only",
+			conf:  MqttConfig{Host: "broker.local"},
+			hosts: []string{"broker.local"},
+		},
+		{
+			name:  "host already present in hosts",
+			conf:  MqttConfig{Host: "broker.local", Hosts: []string{"broker.local", "backup.local"}},
+			hosts: []string{"broker.local", "backup.local"},
+		},
+		{
+			name:  "host appended to hosts",
+			conf:  MqttConfig{Host: "broker.local", Hosts: []string{"backup.local"}},
+			hosts: []string{"backup.local", "broker.local"},
+		},
+		{
+			name:  "no legacy host",
+			conf:  MqttConfig{Hosts: []string{"backup.local"}},
+			hosts: []string{"backup.local"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.conf.FoldHosts()
+			if len(tc.conf.Hosts) != len(tc.hosts) {
+				t.Fatalf("expected Hosts=%v, got %v", tc.hosts, tc.conf.Hosts)
+			}
+			for i, host := range tc.hosts {
+				if tc.conf.Hosts[i] != host {
+					t.Errorf("expected Hosts=%v, got %v", tc.hosts, tc.conf.Hosts)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package config
+
+const defaultAioPollingIntervalMs = 500
+
+type SensorConfig struct {
+	AioPollingIntervalMs int `json:"aio_polling_interval_ms,omitempty" yaml:"aio_polling_interval_ms,omitempty" validate:"min=0"`
+}
+
+func defaultSensorConfig() SensorConfig {
+	return SensorConfig{
+		AioPollingIntervalMs: defaultAioPollingIntervalMs,
+	}
+}
+
+func (conf *SensorConfig) ConfigFromEnv() {
+	pollingIntervalMs, err := fromEnvInt("AIO_POLLING_INTERVAL_MS")
+	if err == nil {
+		conf.AioPollingIntervalMs = pollingIntervalMs
+	}
+}
+
+func (conf *SensorConfig) Validate() error {
+	return nil
+}
+
+func (conf *SensorConfig) Print() {
+	currentLogger().Info("configuration", "aio_polling_interval_ms", conf.AioPollingIntervalMs)
+}
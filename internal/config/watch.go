@@ -0,0 +1,165 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	reAddAttempts = 5
+	reAddBackoff  = 20 * time.Millisecond
+)
+
+var (
+	reloadsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "gobot_lux_config_reloads_total",
+		Help: "Total number of configuration reload attempts, partitioned by result.",
+	}, []string{"result"})
+
+	live atomic.Pointer[Config]
+)
+
+// Current returns the most recently loaded and validated config. It is nil until
+// Watch has completed its initial load.
+func Current() *Config {
+	return live.Load()
+}
+
+// Watch loads the config at path, validates it and then watches it for writes/renames.
+// On every such event the file is reparsed (JSON or YAML, dispatched via LoadConfig)
+// and revalidated; only a config that passes Validate() is swapped into the atomic
+// pointer returned by Current and handed to onChange. A reload that fails to parse or
+// validate is logged and the previously active config keeps serving. The returned stop
+// func releases the underlying fsnotify watcher and must be called to avoid leaking it.
+func Watch(path string, onChange func(*Config)) (stop func() error, err error) {
+	initial, err := LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not load initial config from %s: %v", path, err)
+	}
+	if err := initial.Validate(); err != nil {
+		return nil, fmt.Errorf("initial config from %s is invalid: %v", path, err)
+	}
+	live.Store(initial)
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("could not create file watcher: %v", err)
+	}
+	if err := watcher.Add(path); err != nil {
+		_ = watcher.Close()
+		return nil, fmt.Errorf("could not watch %s: %v", path, err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				// A Rename or Remove on the watched path itself - the standard
+				// write-to-temp-then-rename-over pattern used by most config
+				// management tools and k8s ConfigMap mounts - invalidates the
+				// fsnotify watch, since it's tied to the old inode. Re-add it so
+				// later writes to the new file at the same path keep being seen.
+				if event.Op&(fsnotify.Rename|fsnotify.Remove) != 0 {
+					if err := reAddWatch(watcher, path); err != nil {
+						currentLogger().Error("could not re-establish config watch", "path", path, "error", err)
+						continue
+					}
+					reload(path, onChange)
+					continue
+				}
+
+				if event.Op&fsnotify.Write == 0 {
+					continue
+				}
+				reload(path, onChange)
+			case watchErr, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				currentLogger().Warn("config watcher error", "error", watchErr)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	return func() error {
+		close(done)
+		return watcher.Close()
+	}, nil
+}
+
+// reAddWatch re-registers path with watcher, retrying briefly since an atomic
+// rename-over-path deploy can be observed a moment before the new file is fully in
+// place.
+func reAddWatch(watcher *fsnotify.Watcher, path string) error {
+	var err error
+	for i := 0; i < reAddAttempts; i++ {
+		if err = watcher.Add(path); err == nil {
+			return nil
+		}
+		time.Sleep(reAddBackoff)
+	}
+	return err
+}
+
+func reload(path string, onChange func(*Config)) {
+	newConf, err := LoadConfig(path)
+	if err != nil {
+		reloadsTotal.WithLabelValues("failure").Inc()
+		currentLogger().Error("could not reload config", "path", path, "error", err)
+		return
+	}
+
+	if err := newConf.Validate(); err != nil {
+		reloadsTotal.WithLabelValues("failure").Inc()
+		currentLogger().Error("reloaded config failed validation", "path", path, "error", err)
+		return
+	}
+
+	old := live.Swap(newConf)
+	reloadsTotal.WithLabelValues("success").Inc()
+	logConfigDiff(old, newConf)
+
+	if onChange != nil {
+		onChange(newConf)
+	}
+}
+
+// logConfigDiff walks old and new field by field and logs every changed leaf value,
+// recursing into the embedded MqttConfig/SensorConfig structs.
+func logConfigDiff(old, new *Config) {
+	if old == nil || new == nil {
+		return
+	}
+	diffFields("", reflect.ValueOf(*old), reflect.ValueOf(*new))
+}
+
+func diffFields(prefix string, oldV, newV reflect.Value) {
+	t := oldV.Type()
+	for i := 0; i < oldV.NumField(); i++ {
+		name := prefix + t.Field(i).Name
+		of := oldV.Field(i)
+		nf := newV.Field(i)
+
+		if of.Kind() == reflect.Struct {
+			diffFields(name+".", of, nf)
+			continue
+		}
+
+		if !reflect.DeepEqual(of.Interface(), nf.Interface()) {
+			currentLogger().Info("config field changed", "field", name, "old", of.Interface(), "new", nf.Interface())
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch_ReloadsOnWrite(t *testing.T) {
+	path := writeTempConfigWithExt(t, `{"placement": "kitchen", "mqtt_host": "127.0.0.1", "mqtt_topic": "home/lux", "metrics_addr": "127.0.0.1:9194"}`, ".json")
+
+	var got *Config
+	changed := make(chan struct{}, 1)
+	stop, err := Watch(path, func(c *Config) {
+		got = c
+		changed <- struct{}{}
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = stop() }()
+
+	if Current().Placement != "kitchen" {
+		t.Fatalf("expected initial load to set Placement=kitchen, got %q", Current().Placement)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"placement": "living_room", "mqtt_host": "127.0.0.1", "mqtt_topic": "home/lux", "metrics_addr": "127.0.0.1:9194"}`), 0o600); err != nil {
+		t.Fatalf("could not rewrite config: %v", err)
+	}
+
+	select {
+	case <-changed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+
+	if got == nil || got.Placement != "living_room" {
+		t.Errorf("expected reloaded Placement=living_room, got %+v", got)
+	}
+	if Current().Placement != "living_room" {
+		t.Errorf("expected Current() to reflect reloaded config, got %q", Current().Placement)
+	}
+}
+
+func TestWatch_SurvivesAtomicRenameDeploy(t *testing.T) {
+	path := writeTempConfigWithExt(t, `{"placement": "kitchen", "mqtt_host": "127.0.0.1", "mqtt_topic": "home/lux", "metrics_addr": "127.0.0.1:9194"}`, ".json")
+
+	changed := make(chan *Config, 2)
+	stop, err := Watch(path, func(c *Config) {
+		changed <- c
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer func() { _ = stop() }()
+
+	if Current().Placement != "kitchen" {
+		t.Fatalf("expected initial load to set Placement=kitchen, got %q", Current().Placement)
+	}
+
+	// Simulate the standard write-to-temp-then-rename-over-path deploy pattern
+	// (k8s ConfigMap mounts, most config management tools): the watched path is
+	// replaced wholesale rather than written in place.
+	tmp := filepath.Join(filepath.Dir(path), "config.tmp")
+	if err := os.WriteFile(tmp, []byte(`{"placement": "living_room", "mqtt_host": "127.0.0.1", "mqtt_topic": "home/lux", "metrics_addr": "127.0.0.1:9194"}`), 0o600); err != nil {
+		t.Fatalf("could not write replacement config: %v", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		t.Fatalf("could not rename replacement config into place: %v", err)
+	}
+
+	select {
+	case got := <-changed:
+		if got.Placement != "living_room" {
+			t.Errorf("expected reloaded Placement=living_room, got %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload across rename")
+	}
+
+	// A second, in-place write after the rename must still be observed - proving
+	// the watch was actually re-established on the new file rather than just
+	// reloading once off the rename event itself.
+	if err := os.WriteFile(path, []byte(`{"placement": "garage", "mqtt_host": "127.0.0.1", "mqtt_topic": "home/lux", "metrics_addr": "127.0.0.1:9194"}`), 0o600); err != nil {
+		t.Fatalf("could not rewrite config after rename: %v", err)
+	}
+
+	select {
+	case got := <-changed:
+		if got.Placement != "garage" {
+			t.Errorf("expected reloaded Placement=garage, got %+v", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload after rename")
+	}
+}
+
+func TestWatch_InvalidConfigRejected(t *testing.T) {
+	path := writeTempConfigWithExt(t, `{}`, ".json")
+
+	_, err := Watch(path, nil)
+	if err == nil {
+		t.Fatal("expected an error for a config missing the required placement field")
+	}
+}